@@ -0,0 +1,240 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/backends/iface"
+	"github.com/RichardKnop/machinery/v1/common"
+	"github.com/RichardKnop/machinery/v1/config"
+	"github.com/RichardKnop/machinery/v1/tasks"
+)
+
+// ErrGroupNotFound ...
+type ErrGroupNotFound struct {
+	groupUUID string
+}
+
+// NewErrGroupNotFound returns new instance of ErrGroupNotFound
+func NewErrGroupNotFound(groupUUID string) ErrGroupNotFound {
+	return ErrGroupNotFound{groupUUID: groupUUID}
+}
+
+// Error implements error interface
+func (e ErrGroupNotFound) Error() string {
+	return fmt.Sprintf("Group not found: %v", e.groupUUID)
+}
+
+// ErrTaskNotFound ...
+type ErrTaskNotFound struct {
+	taskUUID string
+}
+
+// NewErrTaskNotFound returns new instance of ErrTaskNotFound
+func NewErrTaskNotFound(taskUUID string) ErrTaskNotFound {
+	return ErrTaskNotFound{taskUUID: taskUUID}
+}
+
+// Error implements error interface
+func (e ErrTaskNotFound) Error() string {
+	return fmt.Sprintf("Task not found: %v", e.taskUUID)
+}
+
+// Backend represents an in-memory result backend meant to be used in tests.
+// Unlike the eager backend it is safe for concurrent use and keeps track of
+// triggered chords, so it behaves like a real result backend would.
+type Backend struct {
+	common.Backend
+	mu     sync.Mutex
+	groups map[string]*tasks.GroupMeta
+	tasks  map[string][]byte
+}
+
+// New creates Backend instance
+func New() iface.Backend {
+	return &Backend{
+		Backend: common.NewBackend(new(config.Config)),
+		groups:  make(map[string]*tasks.GroupMeta),
+		tasks:   make(map[string][]byte),
+	}
+}
+
+// InitGroup creates and saves a group meta data object
+func (b *Backend) InitGroup(groupUUID string, taskUUIDs []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.groups[groupUUID] = &tasks.GroupMeta{
+		GroupUUID: groupUUID,
+		TaskUUIDs: append([]string(nil), taskUUIDs...),
+		CreatedAt: time.Now().UTC(),
+	}
+	return nil
+}
+
+// GroupCompleted returns true if all tasks in a group finished
+func (b *Backend) GroupCompleted(groupUUID string, groupTaskCount int) (bool, error) {
+	taskStates, err := b.GroupTaskStates(groupUUID, groupTaskCount)
+	if err != nil {
+		return false, err
+	}
+
+	var countSuccessTasks = 0
+	for _, taskState := range taskStates {
+		if taskState.IsCompleted() {
+			countSuccessTasks++
+		}
+	}
+
+	return countSuccessTasks == groupTaskCount, nil
+}
+
+// GroupTaskStates returns states of all tasks in the group
+func (b *Backend) GroupTaskStates(groupUUID string, groupTaskCount int) ([]*tasks.TaskState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	groupMeta, ok := b.groups[groupUUID]
+	if !ok {
+		return nil, NewErrGroupNotFound(groupUUID)
+	}
+
+	taskStates := make([]*tasks.TaskState, 0, groupTaskCount)
+	for _, taskUUID := range groupMeta.TaskUUIDs {
+		taskState, err := b.getState(taskUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		taskStates = append(taskStates, taskState)
+	}
+
+	return taskStates, nil
+}
+
+// TriggerChord flags chord as triggered in the backend storage to make sure
+// chord is never trigerred multiple times. Returns a boolean flag to indicate
+// whether the worker should trigger chord (true) or no if it has been triggered
+// already (false)
+func (b *Backend) TriggerChord(groupUUID string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	groupMeta, ok := b.groups[groupUUID]
+	if !ok {
+		return false, NewErrGroupNotFound(groupUUID)
+	}
+
+	// Chord has already been triggered, return false (should not trigger again)
+	if groupMeta.ChordTriggered {
+		return false, nil
+	}
+
+	groupMeta.ChordTriggered = true
+	return true, nil
+}
+
+// SetStatePending updates task state to PENDING
+func (b *Backend) SetStatePending(signature *tasks.Signature) error {
+	taskState := tasks.NewPendingTaskState(signature)
+	return b.updateState(taskState)
+}
+
+// SetStateReceived updates task state to RECEIVED
+func (b *Backend) SetStateReceived(signature *tasks.Signature) error {
+	taskState := tasks.NewReceivedTaskState(signature)
+	return b.updateState(taskState)
+}
+
+// SetStateStarted updates task state to STARTED
+func (b *Backend) SetStateStarted(signature *tasks.Signature) error {
+	taskState := tasks.NewStartedTaskState(signature)
+	return b.updateState(taskState)
+}
+
+// SetStateRetry updates task state to RETRY
+func (b *Backend) SetStateRetry(signature *tasks.Signature) error {
+	taskState := tasks.NewRetryTaskState(signature)
+	return b.updateState(taskState)
+}
+
+// SetStateSuccess updates task state to SUCCESS
+func (b *Backend) SetStateSuccess(signature *tasks.Signature, results []*tasks.TaskResult) error {
+	taskState := tasks.NewSuccessTaskState(signature, results)
+	return b.updateState(taskState)
+}
+
+// SetStateFailure updates task state to FAILURE
+func (b *Backend) SetStateFailure(signature *tasks.Signature, err string) error {
+	taskState := tasks.NewFailureTaskState(signature, err)
+	return b.updateState(taskState)
+}
+
+// GetState returns the latest task state
+func (b *Backend) GetState(taskUUID string) (*tasks.TaskState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.getState(taskUUID)
+}
+
+// PurgeState deletes stored task state
+func (b *Backend) PurgeState(taskUUID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.tasks[taskUUID]; !ok {
+		return NewErrTaskNotFound(taskUUID)
+	}
+
+	delete(b.tasks, taskUUID)
+	return nil
+}
+
+// PurgeGroupMeta deletes stored group meta data
+func (b *Backend) PurgeGroupMeta(groupUUID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.groups[groupUUID]; !ok {
+		return NewErrGroupNotFound(groupUUID)
+	}
+
+	delete(b.groups, groupUUID)
+	return nil
+}
+
+// getState decodes a stored task state, the caller must hold b.mu
+func (b *Backend) getState(taskUUID string) (*tasks.TaskState, error) {
+	encoded, ok := b.tasks[taskUUID]
+	if !ok {
+		return nil, NewErrTaskNotFound(taskUUID)
+	}
+
+	state := new(tasks.TaskState)
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(state); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal task state: %v", err)
+	}
+
+	return state, nil
+}
+
+// updateState stores the task state JSON encoded, the same way a real
+// backend would, so results come back with the usual json.Number values
+func (b *Backend) updateState(taskState *tasks.TaskState) error {
+	encoded, err := json.Marshal(taskState)
+	if err != nil {
+		return fmt.Errorf("Marshal task state error: %v", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tasks[taskState.TaskUUID] = encoded
+	return nil
+}
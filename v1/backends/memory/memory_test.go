@@ -0,0 +1,138 @@
+package memory_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/RichardKnop/machinery/v1/backends/memory"
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAndGetState(t *testing.T) {
+	backend := memory.New()
+	signature := &tasks.Signature{UUID: "testTaskUUID", Name: "add"}
+
+	_, err := backend.GetState(signature.UUID)
+	assert.Equal(t, memory.NewErrTaskNotFound(signature.UUID), err)
+
+	backend.SetStatePending(signature)
+	taskState, err := backend.GetState(signature.UUID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tasks.StatePending, taskState.State)
+		assert.Equal(t, "add", taskState.TaskName)
+	}
+
+	backend.SetStateReceived(signature)
+	taskState, err = backend.GetState(signature.UUID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tasks.StateReceived, taskState.State)
+	}
+
+	backend.SetStateStarted(signature)
+	taskState, err = backend.GetState(signature.UUID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tasks.StateStarted, taskState.State)
+	}
+
+	backend.SetStateRetry(signature)
+	taskState, err = backend.GetState(signature.UUID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tasks.StateRetry, taskState.State)
+	}
+
+	backend.SetStateFailure(signature, "Some error")
+	taskState, err = backend.GetState(signature.UUID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tasks.StateFailure, taskState.State)
+		assert.Equal(t, "Some error", taskState.Error)
+	}
+
+	taskResults := []*tasks.TaskResult{{Type: "int64", Value: 2}}
+	backend.SetStateSuccess(signature, taskResults)
+	taskState, err = backend.GetState(signature.UUID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tasks.StateSuccess, taskState.State)
+		if assert.Len(t, taskState.Results, 1) {
+			assert.Equal(t, json.Number("2"), taskState.Results[0].Value)
+		}
+	}
+
+	assert.NoError(t, backend.PurgeState(signature.UUID))
+	_, err = backend.GetState(signature.UUID)
+	assert.Equal(t, memory.NewErrTaskNotFound(signature.UUID), err)
+}
+
+func TestGroupCompleted(t *testing.T) {
+	backend := memory.New()
+	groupUUID := "testGroupUUID"
+	task1 := &tasks.Signature{UUID: "testTaskUUID1", GroupUUID: groupUUID}
+	task2 := &tasks.Signature{UUID: "testTaskUUID2", GroupUUID: groupUUID}
+
+	groupCompleted, err := backend.GroupCompleted(groupUUID, 2)
+	assert.Equal(t, memory.NewErrGroupNotFound(groupUUID), err)
+	assert.False(t, groupCompleted)
+
+	backend.InitGroup(groupUUID, []string{task1.UUID, task2.UUID})
+
+	groupCompleted, err = backend.GroupCompleted(groupUUID, 2)
+	assert.Equal(t, memory.NewErrTaskNotFound(task1.UUID), err)
+	assert.False(t, groupCompleted)
+
+	backend.SetStatePending(task1)
+	backend.SetStateStarted(task2)
+	groupCompleted, err = backend.GroupCompleted(groupUUID, 2)
+	if assert.NoError(t, err) {
+		assert.False(t, groupCompleted)
+	}
+
+	backend.SetStateSuccess(task1, []*tasks.TaskResult{})
+	backend.SetStateFailure(task2, "Some error")
+	groupCompleted, err = backend.GroupCompleted(groupUUID, 2)
+	if assert.NoError(t, err) {
+		assert.True(t, groupCompleted)
+	}
+
+	taskStates, err := backend.GroupTaskStates(groupUUID, 2)
+	if assert.NoError(t, err) && assert.Len(t, taskStates, 2) {
+		assert.Equal(t, tasks.StateSuccess, taskStates[0].State)
+		assert.Equal(t, tasks.StateFailure, taskStates[1].State)
+	}
+
+	assert.NoError(t, backend.PurgeGroupMeta(groupUUID))
+	_, err = backend.GroupTaskStates(groupUUID, 2)
+	assert.Equal(t, memory.NewErrGroupNotFound(groupUUID), err)
+}
+
+func TestTriggerChord(t *testing.T) {
+	backend := memory.New()
+	groupUUID := "testGroupUUID"
+
+	_, err := backend.TriggerChord(groupUUID)
+	assert.Equal(t, memory.NewErrGroupNotFound(groupUUID), err)
+
+	backend.InitGroup(groupUUID, []string{"testTaskUUID1", "testTaskUUID2"})
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		triggered int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shouldTrigger, err := backend.TriggerChord(groupUUID)
+			assert.NoError(t, err)
+			if shouldTrigger {
+				mu.Lock()
+				triggered++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, triggered)
+}